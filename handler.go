@@ -64,7 +64,7 @@ func (redis *Redis) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dns.M
 
 	location := redis.findLocation(qname, z)
 	if len(location) == 0 { // empty, no results
-		return redis.errorResponse(state, zone, dns.RcodeNameError, nil)
+		return redis.denialResponse(state, z)
 	}
 
 	answers := make([]dns.RR, 0, 10)
@@ -95,6 +95,8 @@ func (redis *Redis) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dns.M
 		answers, extras = redis.SOA(qname, z, record)
 	case "CAA":
 		answers, extras = redis.CAA(qname, z, record)
+	case "NSEC3PARAM":
+		answers, extras = redis.NSEC3PARAM(qname, z, record)
 
 	default:
 		return redis.errorResponse(state, zone, dns.RcodeNotImplemented, nil)
@@ -106,6 +108,9 @@ func (redis *Redis) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dns.M
 
 	m.Answer = append(m.Answer, answers...)
 	m.Extra = append(m.Extra, extras...)
+	if len(m.Answer) == 0 && state.Do() {
+		m.Ns = append(m.Ns, redis.nsec3Denial(qname, z, record)...)
+	}
 
 	state.SizeAndDo(m)
 	m = state.Scrub(m)
@@ -116,6 +121,21 @@ func (redis *Redis) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dns.M
 // Name implements the Handler interface.
 func (redis *Redis) Name() string { return "redis" }
 
+// denialResponse writes an NXDOMAIN for the query, including the NSEC3 proof
+// of non-existence when the client set the DO bit.
+func (redis *Redis) denialResponse(state request.Request, z *Zone) (int, error) {
+	m := new(dns.Msg)
+	m.SetRcode(state.Req, dns.RcodeNameError)
+	m.Authoritative, m.RecursionAvailable, m.Compress = true, false, true
+	if state.Do() {
+		m.Ns = append(m.Ns, redis.nsec3Denial(state.Name(), z, nil)...)
+	}
+
+	state.SizeAndDo(m)
+	_ = state.W.WriteMsg(m)
+	return dns.RcodeSuccess, nil
+}
+
 func (redis *Redis) errorResponse(state request.Request, zone string, rcode int, err error) (int, error) {
 	m := new(dns.Msg)
 	m.SetRcode(state.Req, rcode)
@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"encoding/base32"
+	"sort"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// nsec3Denial returns the NSEC3 records proving a negative answer for qname.
+// A nil record means the name does not exist (NXDOMAIN), otherwise record
+// holds the data found for qname and the answer is NODATA.
+//
+// The records are "white lies" (RFC 7129, section 5.5): every covering NSEC3
+// spans just the hash being denied, so the zone cannot be walked. Nothing is
+// returned when the zone apex has no NSEC3PARAM configured.
+func (redis *Redis) nsec3Denial(qname string, z *Zone, record *Record) []dns.RR {
+	apex := redis.get(z.Name, z)
+	if apex == nil || apex.NSEC3PARAM.Hash == 0 {
+		return nil
+	}
+	param := &apex.NSEC3PARAM
+	ttl := redis.minTtl(apex.SOA.MinTtl)
+
+	if record != nil {
+		return []dns.RR{redis.nsec3Match(qname, z, param, ttl, recordTypes(qname, z, record))}
+	}
+
+	ce, nc := closestEncloser(qname, z)
+	ceRecord := redis.get(redis.findLocation(ce, z), z)
+	return []dns.RR{
+		redis.nsec3Match(ce, z, param, ttl, recordTypes(ce, z, ceRecord)),
+		redis.nsec3Cover(nc, z, param, ttl),
+		redis.nsec3Cover("*."+ce, z, param, ttl),
+	}
+}
+
+// nsec3Match returns an NSEC3 record whose owner is the hash of name.
+func (redis *Redis) nsec3Match(name string, z *Zone, param *NSEC3PARAM_Record, ttl uint32, types []uint16) dns.RR {
+	hash := dns.HashName(name, param.Hash, param.Iterations, nsec3Salt(param))
+	return redis.nsec3(hash, nsec3Shift(hash, 1), z, param, ttl, types)
+}
+
+// nsec3Cover returns an NSEC3 record whose interval covers only the hash of name.
+func (redis *Redis) nsec3Cover(name string, z *Zone, param *NSEC3PARAM_Record, ttl uint32) dns.RR {
+	hash := dns.HashName(name, param.Hash, param.Iterations, nsec3Salt(param))
+	return redis.nsec3(nsec3Shift(hash, -1), nsec3Shift(hash, 1), z, param, ttl, nil)
+}
+
+func (redis *Redis) nsec3(owner, next string, z *Zone, param *NSEC3PARAM_Record, ttl uint32, types []uint16) dns.RR {
+	salt := nsec3Salt(param)
+	r := new(dns.NSEC3)
+	r.Hdr = dns.RR_Header{Name: strings.ToLower(owner) + "." + z.Name, Rrtype: dns.TypeNSEC3,
+		Class: dns.ClassINET, Ttl: ttl}
+	r.Hash = param.Hash
+	r.Flags = param.Flags
+	r.Iterations = param.Iterations
+	r.Salt = salt
+	r.SaltLength = uint8(len(salt) / 2)
+	r.NextDomain = next
+	r.HashLength = uint8(base32.HexEncoding.WithPadding(base32.NoPadding).DecodedLen(len(next)))
+	r.TypeBitMap = types
+	return r
+}
+
+// closestEncloser returns the closest existing ancestor of qname within zone
+// z along with the next closer name, i.e. the ancestor's child on the path
+// to qname.
+func closestEncloser(qname string, z *Zone) (ce, nc string) {
+	labels := dns.SplitDomainName(qname)
+	for i := 1; i < len(labels); i++ {
+		name := dns.Fqdn(strings.Join(labels[i:], "."))
+		if name == z.Name || keyExists(strings.TrimSuffix(name, "."+z.Name), z) {
+			return name, dns.Fqdn(strings.Join(labels[i-1:], "."))
+		}
+	}
+	return z.Name, qname
+}
+
+// recordTypes returns the sorted type bitmap for the data held at name.
+func recordTypes(name string, z *Zone, record *Record) []uint16 {
+	var types []uint16
+	if name == z.Name {
+		types = append(types, dns.TypeSOA)
+	}
+	if record == nil {
+		return types
+	}
+	if len(record.A) > 0 {
+		types = append(types, dns.TypeA)
+	}
+	if len(record.AAAA) > 0 {
+		types = append(types, dns.TypeAAAA)
+	}
+	if len(record.TXT) > 0 {
+		types = append(types, dns.TypeTXT)
+	}
+	if len(record.CNAME) > 0 {
+		types = append(types, dns.TypeCNAME)
+	}
+	if len(record.NS) > 0 {
+		types = append(types, dns.TypeNS)
+	}
+	if len(record.MX) > 0 {
+		types = append(types, dns.TypeMX)
+	}
+	if len(record.SRV) > 0 {
+		types = append(types, dns.TypeSRV)
+	}
+	if len(record.CAA) > 0 {
+		types = append(types, dns.TypeCAA)
+	}
+	if record.NSEC3PARAM.Hash != 0 {
+		types = append(types, dns.TypeNSEC3PARAM)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// nsec3Shift adds delta to the base32hex encoded hash, wrapping around at
+// either end of the hash space.
+func nsec3Shift(hash string, delta int) string {
+	enc := base32.HexEncoding.WithPadding(base32.NoPadding)
+	b, err := enc.DecodeString(strings.ToUpper(hash))
+	if err != nil {
+		return hash
+	}
+	for i := len(b) - 1; i >= 0; i-- {
+		old := b[i]
+		b[i] += byte(delta)
+		if (delta > 0 && b[i] > old) || (delta < 0 && b[i] < old) {
+			break
+		}
+	}
+	return enc.EncodeToString(b)
+}
+
+// nsec3Salt returns the salt as hex, with "-" mapped to the empty salt as in
+// presentation format.
+func nsec3Salt(param *NSEC3PARAM_Record) string {
+	if param.Salt == "-" {
+		return ""
+	}
+	return strings.ToUpper(param.Salt)
+}
@@ -295,6 +295,23 @@ func (redis *Redis) CAA(name string, z *Zone, record *Record) (answers, extras [
 	return
 }
 
+func (redis *Redis) NSEC3PARAM(name string, z *Zone, record *Record) (answers, extras []dns.RR) {
+	if record == nil || record.NSEC3PARAM.Hash == 0 {
+		return
+	}
+	salt := nsec3Salt(&record.NSEC3PARAM)
+	r := new(dns.NSEC3PARAM)
+	r.Hdr = dns.RR_Header{Name: dns.Fqdn(name), Rrtype: dns.TypeNSEC3PARAM,
+		Class: dns.ClassINET, Ttl: redis.minTtl(record.NSEC3PARAM.Ttl)}
+	r.Hash = record.NSEC3PARAM.Hash
+	r.Flags = record.NSEC3PARAM.Flags
+	r.Iterations = record.NSEC3PARAM.Iterations
+	r.Salt = salt
+	r.SaltLength = uint8(len(salt) / 2)
+	answers = append(answers, r)
+	return
+}
+
 func (redis *Redis) AXFR(z *Zone) (records []dns.RR) {
 	//soa, _ := redis.SOA(z.Name, z, record)
 	soa := make([]dns.RR, 0)
@@ -17,6 +17,8 @@ type Record struct {
 	SRV   []SRV_Record   `json:"srv,omitempty"`
 	CAA   []CAA_Record   `json:"caa,omitempty"`
 	SOA   SOA_Record     `json:"soa,omitempty"`
+
+	NSEC3PARAM NSEC3PARAM_Record `json:"nsec3param,omitempty"`
 }
 
 type A_Record struct {
@@ -73,3 +75,11 @@ type CAA_Record struct {
 	Tag   string `json:"tag"`
 	Value string `json:"value"`
 }
+
+type NSEC3PARAM_Record struct {
+	Ttl        uint32 `json:"ttl,omitempty"`
+	Hash       uint8  `json:"hash"`
+	Flags      uint8  `json:"flags"`
+	Iterations uint16 `json:"iterations"`
+	Salt       string `json:"salt"`
+}